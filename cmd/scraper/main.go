@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/jonathanbell/stonks/internal/db"
+	"github.com/jonathanbell/stonks/internal/logger"
+	"github.com/jonathanbell/stonks/internal/repository"
+	"github.com/jonathanbell/stonks/internal/scraper"
+	"github.com/jonathanbell/stonks/internal/store"
+	"github.com/jonathanbell/stonks/internal/store/timescale"
+)
+
+// requestsPerSecond is how many requests per second each host is allowed
+// across all sources that happen to share it.
+const requestsPerSecond = 1.0
+
+// postgresCompactionInterval is how often PostgresSink trims the logs
+// table back down to its row cap.
+const postgresCompactionInterval = 10 * time.Minute
+
+// earningsHistoryCompactionInterval is how often the timescale store drops
+// raw earnings_history rows older than earningsHistoryMaxAge.
+const earningsHistoryCompactionInterval = 24 * time.Hour
+
+// earningsHistoryMaxAge is how long raw earnings_history rows are kept
+// before CompactOlderThan drops them, relying on the continuous aggregates
+// to retain the weekly/monthly summaries.
+const earningsHistoryMaxAge = 2 * 365 * 24 * time.Hour
+
+func hasNetworkConnection() bool {
+	// Try a DNS lookup on a very well known domain
+	_, err := net.LookupHost("google.com")
+	return err == nil
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	audit := logger.NewAuditor(logger.NewFileSink("log.txt"), logger.NewSlogSink(slog.Default()))
+	audit.Info(ctx, "Earnings scraper initialized...")
+
+	debugFlag := flag.Bool("debug", false, "Enable debug mode")
+	workersFlag := flag.Int("workers", 5, "Number of stocks to fetch concurrently per source")
+	flag.Parse()
+
+	if *debugFlag {
+		fmt.Println("Debug mode enabled")
+	}
+
+	if !hasNetworkConnection() {
+		audit.Fatal(ctx, "No network connection detected")
+		return
+	}
+
+	conn, err := db.Connect()
+	if err != nil {
+		audit.Fatal(ctx, "Could not connect to the database", "error", err.Error())
+		return
+	}
+	defer conn.Close()
+
+	logRepo := repository.NewLogRepository(conn)
+	postgresSink := logger.NewPostgresSink(logRepo)
+	audit.AddSink(postgresSink)
+	go runPostgresCompaction(ctx, postgresSink, audit)
+
+	errorCount, err := postgresSink.CountErrors()
+	if err != nil {
+		audit.Fatal(ctx, "Could not count errors in the loggers table", "error", err.Error())
+		return
+	}
+	if errorCount > 9 {
+		audit.Error(ctx, "Too many error logs exist in the loggers table. Exiting until next time")
+		return
+	}
+
+	stockRepo := repository.NewStockRepository(conn)
+	earningsDateRepo := repository.NewEarningsDateRepository(conn)
+	stockSourceStateRepo := repository.NewStockSourceStateRepository(conn)
+
+	httpClient := &http.Client{Transport: scraper.NewHostRateLimiter(requestsPerSecond, 1, nil)}
+
+	scheduler := scraper.NewScheduler(stockRepo, earningsDateRepo, stockSourceStateRepo, audit, *workersFlag, *debugFlag)
+	scheduler.AddSource(scraper.NewYahooSource(httpClient), 60)
+	scheduler.AddSource(scraper.NewNasdaqSource(httpClient), 60)
+	scheduler.AddSource(scraper.NewSecEdgarSource(httpClient), 300)
+
+	scheduler.OnNewEarningsDate(func(stockID uint, dt time.Time) {
+		audit.Info(ctx, "New earnings date discovered", "stock_id", stockID, "earnings_datetime", dt.Format(time.RFC3339))
+	})
+
+	if os.Getenv("DB_DRIVER") == "timescale" {
+		tsStore, err := timescale.Connect(ctx)
+		if err != nil {
+			audit.Fatal(ctx, "Could not connect to the timescale store", "error", err.Error())
+			return
+		}
+		defer tsStore.Close()
+
+		registerTimeSeriesRecording(scheduler, tsStore, audit)
+		go runEarningsHistoryCompaction(ctx, tsStore, audit)
+	}
+
+	scheduler.Run(ctx)
+}
+
+// runPostgresCompaction trims the logs table down to its row cap on a
+// ticker, rather than checking on every write the way the old DbLogger did.
+func runPostgresCompaction(ctx context.Context, sink *logger.PostgresSink, audit *logger.Auditor) {
+	ticker := time.NewTicker(postgresCompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := sink.Compact(); err != nil {
+				audit.Error(ctx, "Could not compact logs table", "error", err.Error())
+			}
+		}
+	}
+}
+
+// registerTimeSeriesRecording wires the scheduler's OnNewEarningsDate hook up
+// to tsStore so that, in addition to the latest-dates-only rows kept by the
+// GORM repositories, every newly-discovered earnings date is also appended
+// to the TimescaleDB hypertable for historical/trend queries. The callback
+// doesn't carry which source produced the date, so it's recorded as
+// "scheduler" rather than e.g. "yahoo" or "nasdaq".
+//
+// This only records dates, not estimates/actuals: nothing in internal/scraper
+// scrapes EPS or revenue, so tsStore.RecordEarningsHistory has no producer to
+// wire up yet. The hypertable and query API (RecordEarningsHistory,
+// GetEarningsHistory) exist for an estimates-scraping source to land on
+// later; runEarningsHistoryCompaction keeps that table's retention policy
+// running in the meantime regardless of whether it has any rows yet.
+func registerTimeSeriesRecording(scheduler *scraper.Scheduler, tsStore store.TimeSeriesStore, audit *logger.Auditor) {
+	scheduler.OnNewEarningsDate(func(stockID uint, dt time.Time) {
+		ctx := context.Background()
+		if err := tsStore.RecordEarningsDate(ctx, stockID, dt, "scheduler"); err != nil {
+			audit.Error(ctx, "Could not record earnings date in timescale store", "stock_id", stockID, "error", err.Error())
+		}
+	})
+}
+
+// runEarningsHistoryCompaction drops raw earnings_history rows older than
+// earningsHistoryMaxAge on a ticker, same pattern as runPostgresCompaction.
+func runEarningsHistoryCompaction(ctx context.Context, tsStore store.TimeSeriesStore, audit *logger.Auditor) {
+	ticker := time.NewTicker(earningsHistoryCompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := tsStore.CompactOlderThan(ctx, earningsHistoryMaxAge); err != nil {
+				audit.Error(ctx, "Could not compact earnings history", "error", err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/joho/godotenv"
+)
+
+// Connect loads the .env file (returning an error if it can't be loaded)
+// and opens a connection to the Postgres database described by the DB_*
+// environment variables.
+func Connect() (*gorm.DB, error) {
+	if err := godotenv.Load(); err != nil {
+		return nil, fmt.Errorf("could not load .env file: %w", err)
+	}
+
+	dbURI := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+		os.Getenv("DB_HOSTNAME"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_DATABASE"),
+		os.Getenv("DB_PASSWORD"),
+	)
+
+	return gorm.Open("postgres", dbURI)
+}
+
+// Migrate creates or updates the tables backing the models in this package.
+func Migrate(conn *gorm.DB) error {
+	return conn.AutoMigrate(&Stock{}, &EarningsDate{}, &LogEntry{}, &StockSourceState{}).Error
+}
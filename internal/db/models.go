@@ -0,0 +1,76 @@
+// Package db holds the GORM models and connection setup shared by the
+// repository layer. It has no knowledge of scraping or logging -- just the
+// schema and how to open a connection to it.
+package db
+
+import "time"
+
+type ExchangeType string
+
+const (
+	NYSE   ExchangeType = "NYSE"
+	NASDAQ ExchangeType = "NASDAQ"
+	TSX    ExchangeType = "TSX"
+)
+
+type Stock struct {
+	ID          uint         `gorm:"column:id;primary_key"`
+	Ticker      string       `gorm:"column:ticker;not null"`
+	CompanyName string       `gorm:"column:company_name"`
+	Exchange    ExchangeType `gorm:"not null"`
+	IsActive    bool         `gorm:"column:is_active;not null;default:true"`
+	CreatedAt   time.Time    `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time    `gorm:"column:updated_at;not null"`
+}
+
+func (Stock) TableName() string {
+	return "stocks"
+}
+
+type EarningsDate struct {
+	ID               uint      `gorm:"column:id;primary_key"`
+	StockID          uint      `gorm:"column:stock_id;not null"`
+	EarningsDateTime time.Time `gorm:"column:earnings_datetime;not null"`
+	Source           string    `gorm:"column:source;not null"`
+}
+
+func (EarningsDate) TableName() string {
+	return "earnings_dates"
+}
+
+// StockSourceState tracks fetch-failure backoff per (stock, source) pair,
+// since one EarningsSource can be down or rate-limited while the others
+// are fetching a given stock just fine. A missing row means that source
+// has no outstanding backoff against that stock.
+type StockSourceState struct {
+	StockID             uint       `gorm:"column:stock_id;primary_key;auto_increment:false"`
+	Source              string     `gorm:"column:source;primary_key"`
+	ConsecutiveFailures int        `gorm:"column:consecutive_failures;not null;default:0"`
+	NextAttemptAt       *time.Time `gorm:"column:next_attempt_at"`
+}
+
+func (StockSourceState) TableName() string {
+	return "stock_source_states"
+}
+
+type ErrorLevel string
+
+const (
+	Debug ErrorLevel = "DEBUG"
+	Info  ErrorLevel = "INFO"
+	Warn  ErrorLevel = "WARN"
+	Error ErrorLevel = "ERROR"
+	Fatal ErrorLevel = "FATAL"
+)
+
+type LogEntry struct {
+	ID        uint       `gorm:"column:id;primary_key"`
+	Timestamp time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	Level     ErrorLevel `gorm:"not null"`
+	Message   string     `gorm:"not null"`
+	StockID   *uint      `gorm:"column:stock_id"`
+}
+
+func (LogEntry) TableName() string {
+	return "logs"
+}
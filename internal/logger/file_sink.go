@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// fileSinkMaxBytes is how large a FileSink's active log file is allowed to
+// grow before it's rotated out to a ".1" backup, rather than tail-scanning
+// the file for a line count on every write.
+const fileSinkMaxBytes = 10 * 1024 * 1024 // 10MB
+
+// FileSink appends each Event as a line of JSON to path, rotating to a
+// single ".1" backup once path passes fileSinkMaxBytes. Write serializes
+// concurrent callers (the scheduler's worker pool logs from multiple
+// goroutines) so two writers can't both observe the file over-size and
+// race each other to rotate it.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Write(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.rotateIfNeeded(); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return fmt.Errorf("could not open log file: %w", err)
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("could not marshal log event: %w", err)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("could not write log event: %w", err)
+	}
+
+	return nil
+}
+
+// rotateIfNeeded renames the current log file out of the way once it's
+// grown past fileSinkMaxBytes, overwriting any previous backup.
+func (s *FileSink) rotateIfNeeded() error {
+	stat, err := os.Stat(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("could not stat log file: %w", err)
+	}
+
+	if stat.Size() < fileSinkMaxBytes {
+		return nil
+	}
+
+	if err := os.Rename(s.path, s.path+".1"); err != nil {
+		return fmt.Errorf("could not rotate log file: %w", err)
+	}
+
+	return nil
+}
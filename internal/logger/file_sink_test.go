@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSinkWritesOneJSONLinePerEvent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	sink := NewFileSink(path)
+
+	if err := sink.Write(context.Background(), Event{Message: "first"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := sink.Write(context.Background(), Event{Message: "second"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	lines := readLines(t, path)
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	if !strings.Contains(lines[0], `"first"`) || !strings.Contains(lines[1], `"second"`) {
+		t.Errorf("lines = %v, want messages \"first\" and \"second\"", lines)
+	}
+}
+
+func TestFileSinkRotatesOnceOverSizeLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.txt")
+	sink := NewFileSink(path)
+
+	oversized := strings.Repeat("x", fileSinkMaxBytes+1)
+	if err := os.WriteFile(path, []byte(oversized), 0666); err != nil {
+		t.Fatalf("could not seed oversized log file: %v", err)
+	}
+
+	if err := sink.Write(context.Background(), Event{Message: "triggers rotation"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	backup := path + ".1"
+	if _, err := os.Stat(backup); err != nil {
+		t.Fatalf("expected rotated backup at %s, got error: %v", backup, err)
+	}
+
+	lines := readLines(t, path)
+	if len(lines) != 1 {
+		t.Fatalf("got %d lines in rotated file, want 1", len(lines))
+	}
+}
+
+func TestFileSinkNoRotationWhenFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist-yet.txt")
+	sink := NewFileSink(path)
+
+	if err := sink.Write(context.Background(), Event{Message: "first write"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+		t.Errorf("expected no backup file to exist, got err = %v", err)
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("could not open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("could not scan %s: %v", path, err)
+	}
+
+	return lines
+}
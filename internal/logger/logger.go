@@ -0,0 +1,112 @@
+// Package logger provides the Auditor used throughout the scraper to
+// record structured events, plus the Sink implementations that decide
+// where those events end up (Postgres, a local file, log/slog, or
+// nowhere at all). A single Auditor call fans out to every registered
+// sink, replacing the old pattern of hand-writing to the database and a
+// file separately at every call site.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// Event is one structured log record, fanned out to every Sink registered
+// on an Auditor. Fields carries whatever extra key/value pairs a call site
+// passed beyond the message itself.
+type Event struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Level     db.ErrorLevel  `json:"level"`
+	Message   string         `json:"message"`
+	StockID   *uint          `json:"stock_id,omitempty"`
+	Source    string         `json:"source,omitempty"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+// Sink receives every Event written through an Auditor. A Sink is
+// responsible for its own batching, caps, and rotation. The Auditor calls
+// Write synchronously, so a slow sink delays the logging call.
+type Sink interface {
+	Write(ctx context.Context, event Event) error
+}
+
+// Auditor fans every logging call out to its registered sinks as a
+// structured Event.
+type Auditor struct {
+	sinks []Sink
+}
+
+// NewAuditor creates an Auditor with an initial set of sinks. Further sinks
+// can be registered afterward with AddSink.
+func NewAuditor(sinks ...Sink) *Auditor {
+	return &Auditor{sinks: sinks}
+}
+
+// AddSink registers an additional sink to receive every future event.
+func (a *Auditor) AddSink(sink Sink) {
+	a.sinks = append(a.sinks, sink)
+}
+
+func (a *Auditor) Debug(ctx context.Context, message string, kvs ...any) {
+	a.write(ctx, db.Debug, message, kvs)
+}
+func (a *Auditor) Info(ctx context.Context, message string, kvs ...any) {
+	a.write(ctx, db.Info, message, kvs)
+}
+func (a *Auditor) Warn(ctx context.Context, message string, kvs ...any) {
+	a.write(ctx, db.Warn, message, kvs)
+}
+func (a *Auditor) Error(ctx context.Context, message string, kvs ...any) {
+	a.write(ctx, db.Error, message, kvs)
+}
+func (a *Auditor) Fatal(ctx context.Context, message string, kvs ...any) {
+	a.write(ctx, db.Fatal, message, kvs)
+}
+
+// write builds an Event out of message and the "key", value, "key", value
+// pairs in kvs, pulling out "stock_id" and "source" as first-class fields
+// since nearly every call site sets one or both, then hands it to every
+// registered sink.
+func (a *Auditor) write(ctx context.Context, level db.ErrorLevel, message string, kvs []any) {
+	event := Event{
+		Timestamp: time.Now(),
+		Level:     level,
+		Message:   message,
+		Fields:    fieldsFromPairs(kvs),
+	}
+
+	if stockID, ok := event.Fields["stock_id"].(uint); ok {
+		event.StockID = &stockID
+		delete(event.Fields, "stock_id")
+	}
+	if source, ok := event.Fields["source"].(string); ok {
+		event.Source = source
+		delete(event.Fields, "source")
+	}
+
+	for _, sink := range a.sinks {
+		if err := sink.Write(ctx, event); err != nil {
+			fmt.Println("Could not write log event to sink: " + err.Error())
+		}
+	}
+}
+
+func fieldsFromPairs(kvs []any) map[string]any {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]any, len(kvs)/2)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			continue
+		}
+		fields[key] = kvs[i+1]
+	}
+
+	return fields
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+type fakeSink struct {
+	events []Event
+}
+
+func (f *fakeSink) Write(ctx context.Context, event Event) error {
+	f.events = append(f.events, event)
+	return nil
+}
+
+func TestAuditorFansOutToEverySink(t *testing.T) {
+	first, second := &fakeSink{}, &fakeSink{}
+	audit := NewAuditor(first, second)
+
+	audit.Error(context.Background(), "something broke")
+
+	if len(first.events) != 1 || len(second.events) != 1 {
+		t.Fatalf("got %d and %d events, want 1 and 1", len(first.events), len(second.events))
+	}
+	if first.events[0].Level != db.Error {
+		t.Errorf("level = %v, want %v", first.events[0].Level, db.Error)
+	}
+}
+
+func TestAuditorAddSinkAfterConstruction(t *testing.T) {
+	first := &fakeSink{}
+	audit := NewAuditor(first)
+
+	second := &fakeSink{}
+	audit.AddSink(second)
+	audit.Info(context.Background(), "hello")
+
+	if len(first.events) != 1 || len(second.events) != 1 {
+		t.Fatalf("got %d and %d events, want 1 and 1", len(first.events), len(second.events))
+	}
+}
+
+func TestAuditorExtractsStockIDAndSource(t *testing.T) {
+	sink := &fakeSink{}
+	audit := NewAuditor(sink)
+
+	audit.Warn(context.Background(), "retrying", "stock_id", uint(42), "source", "nasdaq", "attempt", 3)
+
+	if len(sink.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(sink.events))
+	}
+
+	event := sink.events[0]
+	if event.StockID == nil || *event.StockID != 42 {
+		t.Errorf("StockID = %v, want 42", event.StockID)
+	}
+	if event.Source != "nasdaq" {
+		t.Errorf("Source = %q, want %q", event.Source, "nasdaq")
+	}
+	if _, ok := event.Fields["stock_id"]; ok {
+		t.Error("stock_id should have been pulled out of Fields")
+	}
+	if event.Fields["attempt"] != 3 {
+		t.Errorf("Fields[attempt] = %v, want 3", event.Fields["attempt"])
+	}
+}
+
+func TestAuditorWithNoKeyValuePairs(t *testing.T) {
+	sink := &fakeSink{}
+	audit := NewAuditor(sink)
+
+	audit.Debug(context.Background(), "no extra fields")
+
+	if len(sink.events) != 1 {
+		t.Fatalf("got %d events, want 1", len(sink.events))
+	}
+	if sink.events[0].Fields != nil {
+		t.Errorf("Fields = %v, want nil", sink.events[0].Fields)
+	}
+}
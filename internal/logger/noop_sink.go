@@ -0,0 +1,9 @@
+package logger
+
+import "context"
+
+// NoopSink discards every event. Useful for tests that need an Auditor but
+// don't care where its events go.
+type NoopSink struct{}
+
+func (NoopSink) Write(ctx context.Context, event Event) error { return nil }
@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jonathanbell/stonks/internal/db"
+	"github.com/jonathanbell/stonks/internal/repository"
+)
+
+// postgresSinkCap is how many rows the logs table is allowed to hold. Unlike
+// the old check-on-every-write behavior, PostgresSink doesn't enforce this
+// itself -- call Compact periodically (see cmd/scraper/main.go) so a write
+// under load isn't paying for a row count every time.
+const postgresSinkCap = 1000
+
+// PostgresSink writes every Event to the logs table.
+type PostgresSink struct {
+	logs repository.LogRepository
+}
+
+func NewPostgresSink(logs repository.LogRepository) *PostgresSink {
+	return &PostgresSink{logs: logs}
+}
+
+func (s *PostgresSink) Write(ctx context.Context, event Event) error {
+	return s.logs.Create(db.LogEntry{
+		Level:   event.Level,
+		Message: formatMessage(event),
+		StockID: event.StockID,
+	})
+}
+
+// formatMessage folds event.Source and event.Fields into the message text,
+// since the logs table's Message column is the only place left to find
+// them once written -- it has no columns of its own for that context.
+func formatMessage(event Event) string {
+	message := event.Message
+	if event.Source != "" {
+		message = fmt.Sprintf("[%s] %s", event.Source, message)
+	}
+
+	if len(event.Fields) == 0 {
+		return message
+	}
+
+	keys := make([]string, 0, len(event.Fields))
+	for key := range event.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", key, event.Fields[key]))
+	}
+
+	return fmt.Sprintf("%s (%s)", message, strings.Join(pairs, ", "))
+}
+
+// CountErrors reports how many ERROR-level rows are currently in the logs
+// table, used by the scraper to back off once too many have accumulated.
+func (s *PostgresSink) CountErrors() (int, error) {
+	return s.logs.CountByLevel(db.Error)
+}
+
+// Compact trims the logs table back down to its newest postgresSinkCap
+// rows.
+func (s *PostgresSink) Compact() error {
+	return s.logs.DeleteOldestBeyond(postgresSinkCap)
+}
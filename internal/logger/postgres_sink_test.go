@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+type fakeLogRepository struct {
+	created []db.LogEntry
+}
+
+func (f *fakeLogRepository) CountByLevel(level db.ErrorLevel) (int, error) {
+	count := 0
+	for _, entry := range f.created {
+		if entry.Level == level {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeLogRepository) Create(entry db.LogEntry) error {
+	f.created = append(f.created, entry)
+	return nil
+}
+
+func (f *fakeLogRepository) DeleteOldestBeyond(limit int) error {
+	if len(f.created) > limit {
+		f.created = f.created[len(f.created)-limit:]
+	}
+	return nil
+}
+
+func TestPostgresSinkFoldsSourceAndFieldsIntoMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  string
+	}{
+		{
+			name:  "message only",
+			event: Event{Message: "could not find active stocks"},
+			want:  "could not find active stocks",
+		},
+		{
+			name:  "source only",
+			event: Event{Message: "retrying", Source: "nasdaq"},
+			want:  "[nasdaq] retrying",
+		},
+		{
+			name:  "source and fields, sorted by key",
+			event: Event{Message: "retrying", Source: "nasdaq", Fields: map[string]any{"attempt": 3, "ticker": "ACME"}},
+			want:  "[nasdaq] retrying (attempt=3, ticker=ACME)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLogRepository{}
+			sink := NewPostgresSink(repo)
+
+			if err := sink.Write(context.Background(), tt.event); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+
+			if got := repo.created[0].Message; got != tt.want {
+				t.Errorf("Message = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresSinkCountErrorsAndCompact(t *testing.T) {
+	repo := &fakeLogRepository{}
+	sink := NewPostgresSink(repo)
+
+	for i := 0; i < 3; i++ {
+		if err := sink.Write(context.Background(), Event{Level: db.Error, Message: "oops"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sink.Write(context.Background(), Event{Level: db.Info, Message: "fine"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	count, err := sink.CountErrors()
+	if err != nil {
+		t.Fatalf("CountErrors() error = %v", err)
+	}
+	if count != 3 {
+		t.Errorf("CountErrors() = %d, want 3", count)
+	}
+
+	if err := sink.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// SlogSink wraps a log/slog.Logger so operators can route audit events to
+// stdout, journald, or anywhere else a slog.Handler can reach.
+type SlogSink struct {
+	logger *slog.Logger
+}
+
+func NewSlogSink(logger *slog.Logger) *SlogSink {
+	return &SlogSink{logger: logger}
+}
+
+func (s *SlogSink) Write(ctx context.Context, event Event) error {
+	args := make([]any, 0, 4+len(event.Fields)*2)
+	if event.StockID != nil {
+		args = append(args, "stock_id", *event.StockID)
+	}
+	if event.Source != "" {
+		args = append(args, "source", event.Source)
+	}
+	for key, value := range event.Fields {
+		args = append(args, key, value)
+	}
+
+	s.logger.Log(ctx, slogLevel(event.Level), event.Message, args...)
+	return nil
+}
+
+func slogLevel(level db.ErrorLevel) slog.Level {
+	switch level {
+	case db.Debug:
+		return slog.LevelDebug
+	case db.Warn:
+		return slog.LevelWarn
+	case db.Error, db.Fatal:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
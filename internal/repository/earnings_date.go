@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// EarningsDateRepository is the scraper's view of the earnings_dates table.
+type EarningsDateRepository interface {
+	FindByStockAndSource(stockID uint, source string) ([]db.EarningsDate, error)
+	Create(earningsDate db.EarningsDate) error
+	Delete(earningsDate db.EarningsDate) error
+}
+
+type gormEarningsDateRepository struct {
+	conn *gorm.DB
+}
+
+func NewEarningsDateRepository(conn *gorm.DB) EarningsDateRepository {
+	return &gormEarningsDateRepository{conn: conn}
+}
+
+func (r *gormEarningsDateRepository) FindByStockAndSource(stockID uint, source string) ([]db.EarningsDate, error) {
+	var earningsDates []db.EarningsDate
+	err := r.conn.Where("stock_id = ? AND source = ?", stockID, source).Find(&earningsDates).Error
+	return earningsDates, err
+}
+
+func (r *gormEarningsDateRepository) Create(earningsDate db.EarningsDate) error {
+	return r.conn.Create(&earningsDate).Error
+}
+
+func (r *gormEarningsDateRepository) Delete(earningsDate db.EarningsDate) error {
+	return r.conn.Delete(&earningsDate).Error
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// LogRepository is the scraper's view of the logs table.
+type LogRepository interface {
+	CountByLevel(level db.ErrorLevel) (int, error)
+	Create(entry db.LogEntry) error
+	DeleteOldestBeyond(limit int) error
+}
+
+type gormLogRepository struct {
+	conn *gorm.DB
+}
+
+func NewLogRepository(conn *gorm.DB) LogRepository {
+	return &gormLogRepository{conn: conn}
+}
+
+func (r *gormLogRepository) CountByLevel(level db.ErrorLevel) (int, error) {
+	var count int
+	err := r.conn.Model(&db.LogEntry{}).Where("level = ?", level).Count(&count).Error
+	return count, err
+}
+
+func (r *gormLogRepository) Create(entry db.LogEntry) error {
+	return r.conn.Create(&entry).Error
+}
+
+// DeleteOldestBeyond removes every row except the newest limit, run
+// periodically rather than on every write.
+func (r *gormLogRepository) DeleteOldestBeyond(limit int) error {
+	return r.conn.Exec(
+		"DELETE FROM logs WHERE id NOT IN (SELECT id FROM logs ORDER BY id DESC LIMIT ?)",
+		limit,
+	).Error
+}
@@ -0,0 +1,60 @@
+// Package repository sits between the scraper and the database. It exposes
+// narrow interfaces (StockRepository, EarningsDateRepository, LogRepository)
+// so the scraper can depend on behavior instead of a concrete *gorm.DB,
+// which makes it possible to mock the database in tests instead of
+// standing up Postgres.
+package repository
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// StockRepository is the scraper's view of the stocks table.
+type StockRepository interface {
+	// StalestActive returns up to limit active stocks that are due for a
+	// fetch via source (no backoff on file for that source, or the
+	// backoff has elapsed), oldest updated_at first.
+	StalestActive(source string, limit int) ([]db.Stock, error)
+	UpdateCompanyName(stock db.Stock, companyName string) error
+	// Deactivate marks a stock inactive everywhere, not just for one
+	// source -- it's reserved for a source confirming the ticker itself
+	// no longer resolves, not an ordinary per-source fetch failure.
+	Deactivate(stock db.Stock) error
+	// RecordSuccess marks the stock as just refreshed.
+	RecordSuccess(stock db.Stock) error
+}
+
+type gormStockRepository struct {
+	conn *gorm.DB
+}
+
+func NewStockRepository(conn *gorm.DB) StockRepository {
+	return &gormStockRepository{conn: conn}
+}
+
+func (r *gormStockRepository) StalestActive(source string, limit int) ([]db.Stock, error) {
+	var stocks []db.Stock
+	err := r.conn.
+		Joins("LEFT JOIN stock_source_states ON stock_source_states.stock_id = stocks.id AND stock_source_states.source = ?", source).
+		Where("stocks.is_active = ? AND (stock_source_states.next_attempt_at IS NULL OR stock_source_states.next_attempt_at <= ?)", true, time.Now()).
+		Order("stocks.updated_at ASC").
+		Limit(limit).
+		Find(&stocks).Error
+	return stocks, err
+}
+
+func (r *gormStockRepository) UpdateCompanyName(stock db.Stock, companyName string) error {
+	return r.conn.Model(&stock).Update("company_name", companyName).Error
+}
+
+func (r *gormStockRepository) Deactivate(stock db.Stock) error {
+	return r.conn.Model(&stock).Update("is_active", false).Error
+}
+
+func (r *gormStockRepository) RecordSuccess(stock db.Stock) error {
+	return r.conn.Model(&stock).Update("updated_at", time.Now()).Error
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/jonathanbell/stonks/internal/db"
+)
+
+// StockSourceStateRepository is the scraper's view of the
+// stock_source_states table, which holds each EarningsSource's own
+// fetch-failure backoff against a stock, independent of every other
+// source.
+type StockSourceStateRepository interface {
+	// ConsecutiveFailures reports how many times in a row source has
+	// failed to fetch stockID, or 0 if there's no backoff on file.
+	ConsecutiveFailures(stockID uint, source string) (int, error)
+	// RecordFailure increments the consecutive-failure count for
+	// (stockID, source) and schedules its next eligible attempt.
+	RecordFailure(stockID uint, source string, nextAttemptAt time.Time) error
+	// RecordSuccess clears any backoff state for (stockID, source).
+	RecordSuccess(stockID uint, source string) error
+}
+
+type gormStockSourceStateRepository struct {
+	conn *gorm.DB
+}
+
+func NewStockSourceStateRepository(conn *gorm.DB) StockSourceStateRepository {
+	return &gormStockSourceStateRepository{conn: conn}
+}
+
+func (r *gormStockSourceStateRepository) find(stockID uint, source string) (db.StockSourceState, error) {
+	var state db.StockSourceState
+	err := r.conn.Where("stock_id = ? AND source = ?", stockID, source).First(&state).Error
+	return state, err
+}
+
+func (r *gormStockSourceStateRepository) ConsecutiveFailures(stockID uint, source string) (int, error) {
+	state, err := r.find(stockID, source)
+	if gorm.IsRecordNotFoundError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return state.ConsecutiveFailures, nil
+}
+
+func (r *gormStockSourceStateRepository) RecordFailure(stockID uint, source string, nextAttemptAt time.Time) error {
+	state, err := r.find(stockID, source)
+	if gorm.IsRecordNotFoundError(err) {
+		return r.conn.Create(&db.StockSourceState{
+			StockID:             stockID,
+			Source:              source,
+			ConsecutiveFailures: 1,
+			NextAttemptAt:       &nextAttemptAt,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.conn.Model(&state).Updates(map[string]interface{}{
+		"consecutive_failures": state.ConsecutiveFailures + 1,
+		"next_attempt_at":      nextAttemptAt,
+	}).Error
+}
+
+func (r *gormStockSourceStateRepository) RecordSuccess(stockID uint, source string) error {
+	return r.conn.Where("stock_id = ? AND source = ?", stockID, source).Delete(&db.StockSourceState{}).Error
+}
@@ -0,0 +1,28 @@
+package scraper
+
+import (
+	"math/rand"
+	"time"
+)
+
+// backoffBase and backoffMax bound the exponential backoff applied after a
+// failed fetch. A stock that keeps failing is retried less and less often,
+// instead of coming back up as "stalest" on every single poll.
+const (
+	backoffBase = 30 * time.Second
+	backoffMax  = 30 * time.Minute
+)
+
+// nextAttemptBackoff returns how long to wait before retrying a stock that
+// has just failed for the consecutiveFailures-th time, doubling the base
+// delay per failure (capped at backoffMax) and adding up to 50% jitter so a
+// batch of stocks that failed together doesn't all retry in lockstep.
+func nextAttemptBackoff(consecutiveFailures int) time.Duration {
+	delay := backoffBase * time.Duration(1<<min(consecutiveFailures, 10))
+	if delay > backoffMax {
+		delay = backoffMax
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay + jitter
+}
@@ -0,0 +1,27 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAttemptBackoff(t *testing.T) {
+	tests := []struct {
+		name                string
+		consecutiveFailures int
+		wantMin             time.Duration
+		wantMax             time.Duration
+	}{
+		{name: "first failure", consecutiveFailures: 1, wantMin: 60 * time.Second, wantMax: 90 * time.Second},
+		{name: "many failures caps out", consecutiveFailures: 100, wantMin: backoffMax, wantMax: backoffMax + backoffMax/2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextAttemptBackoff(tt.consecutiveFailures)
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("nextAttemptBackoff(%d) = %v, want between %v and %v", tt.consecutiveFailures, got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NasdaqSource hits Nasdaq's public earnings calendar JSON endpoint. It's
+// less information-rich than Yahoo (no company name) but far less likely
+// to break on a markup change.
+type NasdaqSource struct {
+	client *http.Client
+}
+
+func NewNasdaqSource(client *http.Client) *NasdaqSource {
+	return &NasdaqSource{client: client}
+}
+
+func (s *NasdaqSource) Name() string {
+	return "nasdaq"
+}
+
+// ProvidesCompanyName is false: Nasdaq's earnings calendar endpoint never
+// returns a company name, so a ticker simply outside its near-term
+// calendar looks identical to one it's never heard of. Zero rows with a
+// 200 status is routine here, not a dead-ticker signal.
+func (s *NasdaqSource) ProvidesCompanyName() bool {
+	return false
+}
+
+type nasdaqEarningsResponse struct {
+	Data struct {
+		Rows []struct {
+			Symbol string `json:"symbol"`
+			Date   string `json:"date"`
+			Time   string `json:"time"`
+		} `json:"rows"`
+	} `json:"data"`
+}
+
+func (s *NasdaqSource) Fetch(ctx context.Context, ticker string) ([]time.Time, string, error) {
+	url := "https://api.nasdaq.com/api/calendar/earnings?symbol=" + ticker
+	request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create new HTTP request: %w", err)
+	}
+	request.Header.Set("User-Agent", randomUserAgent())
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not execute the request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("nasdaq returned non-200 status code: %d", response.StatusCode)
+	}
+
+	var parsed nasdaqEarningsResponse
+	if err := json.NewDecoder(response.Body).Decode(&parsed); err != nil {
+		return nil, "", fmt.Errorf("could not decode nasdaq response body: %w", err)
+	}
+
+	return parseNasdaqRows(parsed, ticker), "", nil
+}
+
+// parseNasdaqRows filters the rows in a decoded Nasdaq response down to the
+// given ticker and resolves each into a UTC timestamp. Split out from
+// Fetch so it can be tested without an HTTP round trip.
+func parseNasdaqRows(parsed nasdaqEarningsResponse, ticker string) []time.Time {
+	loc, _ := time.LoadLocation("America/New_York")
+
+	var discoveredEarningsDates []time.Time
+	for _, row := range parsed.Data.Rows {
+		if !strings.EqualFold(row.Symbol, ticker) {
+			continue
+		}
+
+		// Nasdaq's "time" field is one of a small set of free-text labels
+		// (time-after-hours, time-pre-market, time-not-supplied) rather
+		// than a clock time, so we only have date-level precision here.
+		dt, err := time.ParseInLocation("2006-01-02", row.Date, loc)
+		if err != nil {
+			continue
+		}
+
+		discoveredEarningsDates = append(discoveredEarningsDates, dt.UTC())
+	}
+
+	return discoveredEarningsDates
+}
@@ -0,0 +1,31 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNasdaqRows(t *testing.T) {
+	parsed := nasdaqEarningsResponse{}
+	parsed.Data.Rows = []struct {
+		Symbol string `json:"symbol"`
+		Date   string `json:"date"`
+		Time   string `json:"time"`
+	}{
+		{Symbol: "acme", Date: "2026-08-05", Time: "time-after-hours"},
+		{Symbol: "OTHER", Date: "2026-08-06", Time: "time-pre-market"},
+		{Symbol: "ACME", Date: "not-a-date", Time: "time-not-supplied"},
+	}
+
+	dates := parseNasdaqRows(parsed, "ACME")
+
+	if len(dates) != 1 {
+		t.Fatalf("got %d dates, want 1", len(dates))
+	}
+
+	loc, _ := time.LoadLocation("America/New_York")
+	want := time.Date(2026, time.August, 5, 0, 0, 0, 0, loc).UTC()
+	if !dates[0].Equal(want) {
+		t.Errorf("date = %v, want %v", dates[0], want)
+	}
+}
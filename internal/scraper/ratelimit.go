@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// HostRateLimiter is an http.RoundTripper that gates outbound requests
+// through a per-host token bucket, so one source (e.g. Yahoo) can be
+// throttled independently of the others even though they may share the
+// same underlying transport.
+type HostRateLimiter struct {
+	next  http.RoundTripper
+	rps   rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewHostRateLimiter builds a rate limiter allowing rps requests per
+// second (with the given burst) to any single host. next is the transport
+// to delegate the actual request to; http.DefaultTransport is used if nil.
+func NewHostRateLimiter(rps float64, burst int, next http.RoundTripper) *HostRateLimiter {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &HostRateLimiter{
+		next:     next,
+		rps:      rate.Limit(rps),
+		burst:    burst,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (h *HostRateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := h.limiterFor(req.URL.Host).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return h.next.RoundTrip(req)
+}
+
+func (h *HostRateLimiter) limiterFor(host string) *rate.Limiter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	limiter, ok := h.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(h.rps, h.burst)
+		h.limiters[host] = limiter
+	}
+
+	return limiter
+}
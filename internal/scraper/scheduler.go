@@ -0,0 +1,313 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jonathanbell/stonks/internal/db"
+	"github.com/jonathanbell/stonks/internal/logger"
+	"github.com/jonathanbell/stonks/internal/repository"
+)
+
+// EarningsDateCallback is invoked once per newly-discovered earnings date,
+// after it has been persisted. Consumers register one via
+// Scheduler.OnNewEarningsDate to push webhooks, enqueue a message, trigger
+// a re-render, etc., without the Scheduler needing to know anything about
+// them.
+type EarningsDateCallback func(stockID uint, dt time.Time)
+
+// sourceSchedule pairs a source with how often (in seconds) the Scheduler
+// should poll it.
+type sourceSchedule struct {
+	source        EarningsSource
+	periodSeconds int
+}
+
+// defaultMaxConsecutiveFailures is how many times in a row every
+// registered source can fail to fetch a given stock before that stock is
+// deactivated.
+const defaultMaxConsecutiveFailures = 5
+
+// Scheduler owns a set of EarningsSources, polls each on its own period,
+// merges whatever dates they discover against what's already on file, and
+// fans new dates out to registered callbacks. Each poll pulls a batch of
+// the workers stalest active stocks and fetches them concurrently.
+type Scheduler struct {
+	stocks        repository.StockRepository
+	earningsDates repository.EarningsDateRepository
+	sourceStates  repository.StockSourceStateRepository
+	audit         *logger.Auditor
+	schedules     []sourceSchedule
+	callbacks     []EarningsDateCallback
+	debug         bool
+	workers       int
+	maxFailures   int
+}
+
+func NewScheduler(stocks repository.StockRepository, earningsDates repository.EarningsDateRepository, sourceStates repository.StockSourceStateRepository, audit *logger.Auditor, workers int, debug bool) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Scheduler{
+		stocks:        stocks,
+		earningsDates: earningsDates,
+		sourceStates:  sourceStates,
+		audit:         audit,
+		workers:       workers,
+		maxFailures:   defaultMaxConsecutiveFailures,
+		debug:         debug,
+	}
+}
+
+// AddSource registers a source to be polled every periodSeconds.
+func (sch *Scheduler) AddSource(source EarningsSource, periodSeconds int) {
+	sch.schedules = append(sch.schedules, sourceSchedule{source: source, periodSeconds: periodSeconds})
+}
+
+// OnNewEarningsDate registers a callback to be fired whenever a source
+// discovers an earnings date we didn't already have on file.
+func (sch *Scheduler) OnNewEarningsDate(cb EarningsDateCallback) {
+	sch.callbacks = append(sch.callbacks, cb)
+}
+
+// Run blocks, polling every registered source on its own ticker until ctx
+// is cancelled. Each source gets an immediate poll before its ticker takes
+// over.
+func (sch *Scheduler) Run(ctx context.Context) {
+	done := make(chan struct{}, len(sch.schedules))
+
+	for _, schedule := range sch.schedules {
+		go func(schedule sourceSchedule) {
+			defer func() { done <- struct{}{} }()
+
+			ticker := time.NewTicker(time.Duration(schedule.periodSeconds) * time.Second)
+			defer ticker.Stop()
+
+			sch.poll(ctx, schedule.source)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					sch.poll(ctx, schedule.source)
+				}
+			}
+		}(schedule)
+	}
+
+	<-ctx.Done()
+	for range sch.schedules {
+		<-done
+	}
+}
+
+// poll pulls a batch of the stalest active stocks and fetches them from
+// source concurrently across sch.workers goroutines, writing each result
+// back to the database independently.
+func (sch *Scheduler) poll(ctx context.Context, source EarningsSource) {
+	stocks, err := sch.stocks.StalestActive(source.Name(), sch.workers)
+	if err != nil {
+		sch.audit.Fatal(ctx, "Could not find active stocks", "error", err.Error())
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, stock := range stocks {
+		wg.Add(1)
+		go func(stock db.Stock) {
+			defer wg.Done()
+			sch.processStock(ctx, source, stock)
+		}(stock)
+	}
+	wg.Wait()
+}
+
+// processStock fetches a single stock from source and merges the result
+// into the database, applying per-source exponential backoff on failure
+// and deactivating the stock outright if source resolves a company name
+// for recognized tickers but came back with neither a name nor any dates,
+// which is our strongest signal the ticker is delisted or otherwise gone.
+func (sch *Scheduler) processStock(ctx context.Context, source EarningsSource, stock db.Stock) {
+	discoveredEarningsDates, companyName, err := source.Fetch(ctx, stock.Ticker)
+	if err != nil {
+		sch.recordFailure(ctx, source, stock, err)
+		return
+	}
+
+	if source.ProvidesCompanyName() && companyName == "" && len(discoveredEarningsDates) == 0 {
+		sch.deactivateDeadTicker(ctx, source, stock)
+		return
+	}
+
+	addedCount, removedCount, ohShit := sch.mergeEarningsDates(ctx, stock, source.Name(), discoveredEarningsDates)
+
+	if companyName != "" {
+		if err := sch.stocks.UpdateCompanyName(stock, companyName); err != nil {
+			sch.audit.Error(ctx, "Could not update company name", "stock_id", stock.ID, "error", err.Error())
+		}
+	}
+
+	if sch.debug {
+		fmt.Println(time.Now())
+		fmt.Println("Source: ", source.Name())
+		fmt.Println("Stock ID: ", stock.ID)
+		fmt.Println("Ticker: ", stock.Ticker)
+		fmt.Printf("Number of added earnings dates: %d\n", addedCount)
+		fmt.Printf("Number of removed earnings dates: %d\n", removedCount)
+		fmt.Println("-----------------------------------")
+	}
+
+	if !ohShit {
+		if err := sch.stocks.RecordSuccess(stock); err != nil {
+			sch.audit.Error(ctx, "Could not update stock after successful fetch", "stock_id", stock.ID, "error", err.Error())
+		}
+		if err := sch.sourceStates.RecordSuccess(stock.ID, source.Name()); err != nil {
+			sch.audit.Error(ctx, "Could not clear source failure state", "stock_id", stock.ID, "source", source.Name(), "error", err.Error())
+		}
+		sch.audit.Info(ctx, "Earnings date scraping completed successfully", "stock_id", stock.ID, "source", source.Name())
+	}
+}
+
+// recordFailure schedules source's next attempt against stock with an
+// exponentially growing delay. Failures are tracked per (stock, source)
+// pair: one source being down or rate-limited shouldn't suppress polling
+// by every other source against the same stock. Only once every
+// registered source has independently racked up more than maxFailures
+// consecutive failures against stock do we deactivate it -- a single dead
+// or rate-limited source can't take the stock down on its own.
+func (sch *Scheduler) recordFailure(ctx context.Context, source EarningsSource, stock db.Stock, cause error) {
+	consecutiveFailures, err := sch.sourceStates.ConsecutiveFailures(stock.ID, source.Name())
+	if err != nil {
+		sch.audit.Error(ctx, "Could not read source failure state", "stock_id", stock.ID, "source", source.Name(), "error", err.Error())
+	}
+	consecutiveFailures++
+
+	nextAttemptAt := time.Now().Add(nextAttemptBackoff(consecutiveFailures))
+	if err := sch.sourceStates.RecordFailure(stock.ID, source.Name(), nextAttemptAt); err != nil {
+		sch.audit.Error(ctx, "Could not record source failure", "stock_id", stock.ID, "source", source.Name(), "error", err.Error())
+	}
+	sch.audit.Error(ctx, cause.Error(), "stock_id", stock.ID, "source", source.Name(), "failure_count", consecutiveFailures)
+
+	if sch.allSourcesExceedFailureLimit(stock.ID) {
+		sch.deactivateAfterRepeatedFailures(ctx, stock)
+	}
+}
+
+// allSourcesExceedFailureLimit reports whether every source registered with
+// the Scheduler has more than maxFailures consecutive failures against
+// stockID. A source whose failure state can't be read is treated as under
+// the limit, so a transient repository error can't itself trigger
+// deactivation.
+func (sch *Scheduler) allSourcesExceedFailureLimit(stockID uint) bool {
+	for _, schedule := range sch.schedules {
+		consecutiveFailures, err := sch.sourceStates.ConsecutiveFailures(stockID, schedule.source.Name())
+		if err != nil || consecutiveFailures <= sch.maxFailures {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deactivateAfterRepeatedFailures marks stock inactive once every
+// registered source has exceeded maxFailures consecutive failures against
+// it, our signal that the ticker isn't transiently unreachable but
+// consistently failing everywhere.
+func (sch *Scheduler) deactivateAfterRepeatedFailures(ctx context.Context, stock db.Stock) {
+	if err := sch.stocks.Deactivate(stock); err != nil {
+		sch.audit.Error(ctx, "Could not deactivate stock", "stock_id", stock.ID, "error", err.Error())
+	}
+	sch.audit.Warn(ctx, fmt.Sprintf("Every source failed to fetch %s more than %d times in a row. Marked as inactive", stock.Ticker, sch.maxFailures), "stock_id", stock.ID)
+}
+
+// deactivateDeadTicker marks stock inactive across every source once
+// source -- which is expected to resolve a company name whenever it
+// recognizes a ticker -- comes back with neither a name nor any dates.
+// This is the strongest signal we have that the ticker is delisted or
+// otherwise gone, so unlike an ordinary fetch failure it isn't scoped to a
+// single source.
+func (sch *Scheduler) deactivateDeadTicker(ctx context.Context, source EarningsSource, stock db.Stock) {
+	if err := sch.stocks.Deactivate(stock); err != nil {
+		sch.audit.Error(ctx, "Could not deactivate stock", "stock_id", stock.ID, "error", err.Error())
+	}
+	sch.audit.Warn(ctx, fmt.Sprintf("Could not find company name for %s. Marked as inactive", stock.Ticker), "stock_id", stock.ID, "source", source.Name())
+}
+
+// mergeEarningsDates reconciles discoveredDates (fresh from source) against
+// what's already on file for stock, deleting rows that disappeared and
+// creating rows that are new. New rows fire every registered
+// OnNewEarningsDate callback once they've been persisted.
+func (sch *Scheduler) mergeEarningsDates(ctx context.Context, stock db.Stock, sourceName string, discoveredDates []time.Time) (addedCount, removedCount int, ohShit bool) {
+	currentEarningsDates, err := sch.earningsDates.FindByStockAndSource(stock.ID, sourceName)
+	if err != nil {
+		sch.audit.Error(ctx, "Could not find earnings dates for stock", "stock_id", stock.ID, "source", sourceName, "error", err.Error())
+		return 0, 0, true
+	}
+
+	// A source coming back with zero dates on an otherwise successful fetch
+	// is routine -- Nasdaq's near-term calendar window goes empty between
+	// earnings seasons, for instance -- not a signal that every previously
+	// discovered date fell through. Treat it as "nothing new to report"
+	// rather than deleting everything on file: wiping here would make the
+	// next poll that rediscovers the same dates recreate them and re-fire
+	// every OnNewEarningsDate callback for dates we'd already reported.
+	if len(discoveredDates) == 0 {
+		return 0, 0, false
+	}
+
+	discoveredEarningsDatesMap := make(map[time.Time]bool)
+	for _, date := range discoveredDates {
+		discoveredEarningsDatesMap[date] = true
+	}
+
+	// Remove dates that are no longer present in the discovered dates
+	for _, currentDate := range currentEarningsDates {
+		if discoveredEarningsDatesMap[currentDate.EarningsDateTime.UTC()] {
+			continue
+		}
+
+		if err := sch.earningsDates.Delete(currentDate); err != nil {
+			sch.audit.Error(ctx, "Could not delete old earnings date", "stock_id", stock.ID, "source", sourceName, "error", err.Error())
+			ohShit = true
+		} else {
+			removedCount++
+		}
+	}
+
+	currentEarningsDatesMap := make(map[time.Time]bool)
+	for _, date := range currentEarningsDates {
+		currentEarningsDatesMap[date.EarningsDateTime.UTC()] = true
+	}
+
+	for _, discoveredDate := range discoveredDates {
+		if currentEarningsDatesMap[discoveredDate] {
+			continue
+		}
+
+		newEarningsDate := db.EarningsDate{
+			StockID:          stock.ID,
+			EarningsDateTime: discoveredDate,
+			Source:           sourceName,
+		}
+		if err := sch.earningsDates.Create(newEarningsDate); err != nil {
+			sch.audit.Error(ctx, "Could not add new earnings date", "stock_id", stock.ID, "source", sourceName, "error", err.Error())
+			ohShit = true
+			continue
+		}
+
+		addedCount++
+		for _, cb := range sch.callbacks {
+			cb(stock.ID, discoveredDate)
+		}
+	}
+
+	if ohShit {
+		sch.audit.Error(ctx, "Something went wrong while updating earnings dates", "stock_id", stock.ID, "source", sourceName)
+	}
+
+	return addedCount, removedCount, ohShit
+}
@@ -0,0 +1,205 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cikIndexTTL is how long the cached ticker-to-CIK index is trusted before
+// lookupCIK re-fetches company_tickers.json. New tickers get listed on it
+// rarely enough that this doesn't need to track real time closely.
+const cikIndexTTL = 24 * time.Hour
+
+// SecEdgarSource looks up the filer's 8-K filings in SEC EDGAR. Companies
+// are required to furnish an 8-K (Item 2.02) within four business days of
+// announcing earnings, so a freshly filed 8-K is a strong (if slightly
+// lagging) signal that an earnings date has occurred. EDGAR doesn't expose
+// a ticker-keyed endpoint directly, so we first resolve the ticker to a
+// CIK via the company_tickers.json index, caching it for cikIndexTTL since
+// the index is a multi-MB download covering every issuer and every stock
+// in the worker pool would otherwise re-fetch it on every poll.
+type SecEdgarSource struct {
+	client *http.Client
+
+	mu        sync.Mutex
+	cikIndex  map[string]secCompanyTicker
+	indexAsOf time.Time
+}
+
+func NewSecEdgarSource(client *http.Client) *SecEdgarSource {
+	return &SecEdgarSource{client: client}
+}
+
+func (s *SecEdgarSource) Name() string {
+	return "sec_edgar"
+}
+
+// ProvidesCompanyName is true: lookupCIK only ever returns an empty company
+// name alongside a non-nil error (no CIK found), so an empty name can't
+// occur on a successful fetch here.
+func (s *SecEdgarSource) ProvidesCompanyName() bool {
+	return true
+}
+
+type secCompanyTicker struct {
+	CIKStr int    `json:"cik_str"`
+	Ticker string `json:"ticker"`
+	Title  string `json:"title"`
+}
+
+type secSubmissions struct {
+	Filings struct {
+		Recent struct {
+			Form       []string `json:"form"`
+			FilingDate []string `json:"filingDate"`
+			Items      []string `json:"items"`
+		} `json:"recent"`
+	} `json:"filings"`
+}
+
+func (s *SecEdgarSource) lookupCIK(ctx context.Context, ticker string) (int, string, error) {
+	index, err := s.cikIndexSnapshot(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	for _, entry := range index {
+		if strings.EqualFold(entry.Ticker, ticker) {
+			return entry.CIKStr, entry.Title, nil
+		}
+	}
+
+	return 0, "", fmt.Errorf("no CIK found for ticker: %s", ticker)
+}
+
+// cikIndexSnapshot returns the ticker-to-CIK index, fetching a fresh copy
+// of company_tickers.json only when the cached one is missing or older
+// than cikIndexTTL. The lock is held only to read or swap the cached
+// snapshot, not across the HTTP round trip, so a refresh doesn't serialize
+// the rest of the worker pool behind a single multi-MB download.
+func (s *SecEdgarSource) cikIndexSnapshot(ctx context.Context) (map[string]secCompanyTicker, error) {
+	if cached, ok := s.cachedCIKIndex(); ok {
+		return cached, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "GET", "https://www.sec.gov/files/company_tickers.json", nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create new HTTP request: %w", err)
+	}
+	request.Header.Set("User-Agent", randomUserAgent())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not execute the request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sec edgar returned non-200 status code: %d", response.StatusCode)
+	}
+
+	var index map[string]secCompanyTicker
+	if err := json.NewDecoder(response.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("could not decode sec edgar ticker index: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cikIndex = index
+	s.indexAsOf = time.Now()
+	s.mu.Unlock()
+
+	return index, nil
+}
+
+// cachedCIKIndex returns the cached index and true if it's still within
+// cikIndexTTL, or nil and false if it needs refreshing.
+func (s *SecEdgarSource) cachedCIKIndex() (map[string]secCompanyTicker, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cikIndex != nil && time.Since(s.indexAsOf) < cikIndexTTL {
+		return s.cikIndex, true
+	}
+
+	return nil, false
+}
+
+func (s *SecEdgarSource) Fetch(ctx context.Context, ticker string) ([]time.Time, string, error) {
+	cik, companyName, err := s.lookupCIK(ctx, ticker)
+	if err != nil {
+		return nil, "", err
+	}
+
+	url := fmt.Sprintf("https://data.sec.gov/submissions/CIK%010d.json", cik)
+	request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, companyName, fmt.Errorf("could not create new HTTP request: %w", err)
+	}
+	request.Header.Set("User-Agent", randomUserAgent())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, companyName, fmt.Errorf("could not execute the request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, companyName, fmt.Errorf("sec edgar returned non-200 status code: %d", response.StatusCode)
+	}
+
+	var submissions secSubmissions
+	if err := json.NewDecoder(response.Body).Decode(&submissions); err != nil {
+		return nil, companyName, fmt.Errorf("could not decode sec edgar submissions: %w", err)
+	}
+
+	return parse8KFilingDates(submissions), companyName, nil
+}
+
+// parse8KFilingDates pulls the filing dates of every 8-K reporting Item
+// 2.02 (Results of Operations and Financial Condition -- the item
+// companies furnish to report earnings) out of a decoded EDGAR submissions
+// document. Split out from Fetch so it can be tested without an HTTP round
+// trip.
+func parse8KFilingDates(submissions secSubmissions) []time.Time {
+	var discoveredEarningsDates []time.Time
+	for i, form := range submissions.Filings.Recent.Form {
+		if form != "8-K" {
+			continue
+		}
+		if i >= len(submissions.Filings.Recent.FilingDate) {
+			continue
+		}
+		if i >= len(submissions.Filings.Recent.Items) || !has8KItem202(submissions.Filings.Recent.Items[i]) {
+			continue
+		}
+
+		dt, err := time.Parse("2006-01-02", submissions.Filings.Recent.FilingDate[i])
+		if err != nil {
+			continue
+		}
+
+		discoveredEarningsDates = append(discoveredEarningsDates, dt.UTC())
+	}
+
+	return discoveredEarningsDates
+}
+
+// has8KItem202 reports whether items -- EDGAR's comma-separated list of the
+// items an 8-K reports, e.g. "2.02,9.01" -- includes Item 2.02. 8-Ks are
+// filed for all sorts of corporate events (officer changes, agreements,
+// etc.), so without this check most "earnings dates" discovered here would
+// actually be unrelated filings.
+func has8KItem202(items string) bool {
+	for _, item := range strings.Split(items, ",") {
+		if strings.TrimSpace(item) == "2.02" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse8KFilingDates(t *testing.T) {
+	submissions := secSubmissions{}
+	submissions.Filings.Recent.Form = []string{"10-Q", "8-K", "8-K", "4"}
+	submissions.Filings.Recent.FilingDate = []string{"2026-06-01", "2026-08-05", "not-a-date"}
+	submissions.Filings.Recent.Items = []string{"", "2.02,9.01", "2.02"}
+
+	dates := parse8KFilingDates(submissions)
+
+	if len(dates) != 1 {
+		t.Fatalf("got %d dates, want 1", len(dates))
+	}
+
+	want := time.Date(2026, time.August, 5, 0, 0, 0, 0, time.UTC)
+	if !dates[0].Equal(want) {
+		t.Errorf("date = %v, want %v", dates[0], want)
+	}
+}
+
+func TestParse8KFilingDatesIgnoresNonEarningsItems(t *testing.T) {
+	submissions := secSubmissions{}
+	submissions.Filings.Recent.Form = []string{"8-K", "8-K"}
+	submissions.Filings.Recent.FilingDate = []string{"2026-06-01", "2026-08-05"}
+	submissions.Filings.Recent.Items = []string{"5.02", "2.02"}
+
+	dates := parse8KFilingDates(submissions)
+
+	if len(dates) != 1 {
+		t.Fatalf("got %d dates, want 1", len(dates))
+	}
+
+	want := time.Date(2026, time.August, 5, 0, 0, 0, 0, time.UTC)
+	if !dates[0].Equal(want) {
+		t.Errorf("date = %v, want %v", dates[0], want)
+	}
+}
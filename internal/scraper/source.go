@@ -0,0 +1,38 @@
+// Package scraper contains the EarningsSource implementations and the
+// Scheduler that polls them, parses whatever HTML or JSON they return, and
+// merges the result into the earnings_dates table via the repository
+// layer.
+package scraper
+
+import (
+	"context"
+	"time"
+)
+
+// EarningsSource is anything that can be asked for the upcoming earnings
+// dates of a ticker. Implementations are free to scrape HTML, call a JSON
+// API, or read structured filings -- the Scheduler doesn't care, it only
+// needs a name (for logging and for the EarningsDate.Source column) and a
+// way to fetch dates plus whatever company name the source happens to know.
+type EarningsSource interface {
+	Name() string
+	Fetch(ctx context.Context, ticker string) ([]time.Time, string, error)
+	// ProvidesCompanyName reports whether this source resolves a company
+	// name whenever it successfully recognizes a ticker. The Scheduler
+	// uses this to tell "this source doesn't track company names" (an
+	// empty result is routine) apart from "this source looked the ticker
+	// up and it doesn't exist" (an empty result is a dead-ticker signal).
+	ProvidesCompanyName() bool
+}
+
+// userAgents is shared by every HTTP-based source so we don't end up with
+// N copies of the same rotation list.
+var userAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36 Edg/126.0.2592.87",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:127.0) Gecko/20100101 Firefox/127.0",
+}
+
+func randomUserAgent() string {
+	return userAgents[time.Now().UnixNano()%int64(len(userAgents))]
+}
@@ -0,0 +1,114 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// YahooSource scrapes the HTML earnings calendar page on finance.yahoo.com.
+// It's the original (and still most complete) source, but its HTML is
+// brittle enough that we no longer want to depend on it exclusively.
+type YahooSource struct {
+	client *http.Client
+}
+
+func NewYahooSource(client *http.Client) *YahooSource {
+	return &YahooSource{client: client}
+}
+
+func (s *YahooSource) Name() string {
+	return "yahoo"
+}
+
+func (s *YahooSource) ProvidesCompanyName() bool {
+	return true
+}
+
+func (s *YahooSource) Fetch(ctx context.Context, ticker string) ([]time.Time, string, error) {
+	url := "https://finance.yahoo.com/calendar/earnings?symbol=" + ticker
+	request, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create new HTTP request: %w", err)
+	}
+	request.Header.Set("User-Agent", randomUserAgent())
+	request.Header.Set("Upgrade-Insecure-Requests", "1")
+	request.Header.Set("Cache-Control", "no-cache")
+	request.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7")
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not execute the request: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("yahoo returned non-200 status code: %d", response.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(response.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not create new document from response body: %w", err)
+	}
+
+	return parseYahooDocument(doc)
+}
+
+// parseYahooDocument pulls the company name and earnings dates out of an
+// already-parsed Yahoo earnings calendar page. Split out from Fetch so it
+// can be exercised directly against fixture HTML in tests.
+func parseYahooDocument(doc *goquery.Document) ([]time.Time, string, error) {
+	companyName := strings.TrimSpace(doc.Find("td[aria-label='Company']").First().Text())
+
+	earningsDatesDomElement := doc.Find("td[aria-label='Earnings Date']")
+	if earningsDatesDomElement.Length() == 0 {
+		return nil, companyName, fmt.Errorf("could not find earnings dates in document")
+	}
+
+	var discoveredEarningsDates []time.Time
+	var parseErr error
+	earningsDatesDomElement.Each(func(i int, sel *goquery.Selection) {
+		if parseErr != nil {
+			return
+		}
+
+		earningsDateRaw := strings.TrimSpace(sel.Text())
+
+		// Check the length of the earnings date string to ensure it's what we are
+		// looking for. (We are scraping after all..)
+		if len(earningsDateRaw) <= 4 {
+			return
+		}
+
+		// Check for a New York timezone suffix (EDT/EST). Anything else is
+		// parsed as UTC and has to end in "UTC" already.
+		hasNyTimezone := strings.HasSuffix(earningsDateRaw, "EDT") || strings.HasSuffix(earningsDateRaw, "EST")
+		loc, _ := time.LoadLocation("America/New_York")
+
+		if hasNyTimezone {
+			// Ensure there is a space between each time and timezone
+			earningsDateRaw = strings.ReplaceAll(earningsDateRaw, "AM", "AM ")
+			earningsDateRaw = strings.ReplaceAll(earningsDateRaw, "PM", "PM ")
+		} else {
+			loc, _ = time.LoadLocation("UTC")
+			earningsDateRaw = strings.ReplaceAll(earningsDateRaw, "UTC", " UTC")
+		}
+
+		dt, err := time.ParseInLocation("Jan 02, 2006, 3 PM MST", earningsDateRaw, loc)
+		if err != nil {
+			parseErr = fmt.Errorf("error parsing earnings date: %w", err)
+			return
+		}
+
+		discoveredEarningsDates = append(discoveredEarningsDates, dt.UTC())
+	})
+	if parseErr != nil {
+		return discoveredEarningsDates, companyName, parseErr
+	}
+
+	return discoveredEarningsDates, companyName, nil
+}
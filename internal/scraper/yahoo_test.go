@@ -0,0 +1,90 @@
+package scraper
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestParseYahooDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		wantCompany string
+		wantDates   []time.Time
+		wantErr     bool
+	}{
+		{
+			name:        "single earnings date",
+			fixture:     "testdata/yahoo_single_date.html",
+			wantCompany: "Acme Corporation",
+			wantDates: []time.Time{
+				time.Date(2026, time.August, 5, 20, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "multiple earnings dates across timezones",
+			fixture:     "testdata/yahoo_multiple_dates.html",
+			wantCompany: "Acme Corporation",
+			wantDates: []time.Time{
+				time.Date(2026, time.August, 5, 20, 0, 0, 0, time.UTC),
+				time.Date(2026, time.November, 4, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:        "no earnings dates present",
+			fixture:     "testdata/yahoo_no_dates.html",
+			wantCompany: "Acme Corporation",
+			wantErr:     true,
+		},
+		{
+			name:        "no company name present",
+			fixture:     "testdata/yahoo_no_company.html",
+			wantCompany: "",
+			wantDates: []time.Time{
+				time.Date(2026, time.August, 5, 20, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(tt.fixture)
+			if err != nil {
+				t.Fatalf("could not open fixture: %v", err)
+			}
+			defer f.Close()
+
+			doc, err := goquery.NewDocumentFromReader(f)
+			if err != nil {
+				t.Fatalf("could not parse fixture: %v", err)
+			}
+
+			dates, company, err := parseYahooDocument(doc)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if company != tt.wantCompany {
+				t.Errorf("company = %q, want %q", company, tt.wantCompany)
+			}
+
+			if len(dates) != len(tt.wantDates) {
+				t.Fatalf("got %d dates, want %d", len(dates), len(tt.wantDates))
+			}
+			for i, d := range dates {
+				if !d.Equal(tt.wantDates[i]) {
+					t.Errorf("date[%d] = %v, want %v", i, d, tt.wantDates[i])
+				}
+			}
+		})
+	}
+}
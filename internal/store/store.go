@@ -0,0 +1,50 @@
+// Package store defines the storage-agnostic interface for retaining
+// historical earnings data. The default GORM/Postgres path (see
+// internal/db and internal/repository) only ever keeps the latest earnings
+// dates per stock; a TimeSeriesStore implementation additionally retains
+// every scraped snapshot so surprise trends can be queried over time.
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// EarningsHistoryRow is one scraped snapshot of a stock's earnings
+// estimate/actual figures, as stored in the earnings_history hypertable.
+type EarningsHistoryRow struct {
+	StockID         uint
+	ReportedAt      time.Time
+	EPSEstimate     *float64
+	EPSActual       *float64
+	RevenueEstimate *float64
+	RevenueActual   *float64
+	SurprisePct     *float64
+}
+
+// TimeSeriesStore is the storage backend used when DB_DRIVER=timescale.
+// Implementations are expected to retain every row written to them (rather
+// than overwriting, the way the GORM repositories do) so historical
+// surprise trends can be reconstructed later.
+type TimeSeriesStore interface {
+	// RecordEarningsDate appends a new earnings_datetime observation for
+	// stockID, tagged with the source that discovered it.
+	RecordEarningsDate(ctx context.Context, stockID uint, earningsDateTime time.Time, source string) error
+
+	// RecordEarningsHistory bulk-inserts a batch of scraped snapshots. No
+	// EarningsSource in this repo scrapes EPS/revenue estimates yet, so
+	// nothing calls this in production today -- it's the write side an
+	// estimates-scraping source can land on once one exists, kept here so
+	// the hypertable and query API don't have to be designed twice.
+	RecordEarningsHistory(ctx context.Context, rows []EarningsHistoryRow) error
+
+	// GetEarningsHistory returns every snapshot recorded for stockID whose
+	// reported_at falls within [from, to].
+	GetEarningsHistory(ctx context.Context, stockID uint, from, to time.Time) ([]EarningsHistoryRow, error)
+
+	// CompactOlderThan drops raw rows older than maxAge, relying on the
+	// continuous aggregates to retain the weekly/monthly summaries.
+	CompactOlderThan(ctx context.Context, maxAge time.Duration) error
+
+	Close()
+}
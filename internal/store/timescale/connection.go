@@ -0,0 +1,47 @@
+// Package timescale implements store.TimeSeriesStore on top of
+// TimescaleDB, using pgx directly (rather than GORM) so we can use
+// COPY-based bulk inserts and talk to continuous aggregates.
+package timescale
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Store is the TimescaleDB-backed implementation of store.TimeSeriesStore.
+type Store struct {
+	pool *pgxpool.Pool
+}
+
+// Connect opens a pool against the TimescaleDB instance described by the
+// DB_* environment variables (the same ones internal/db.Connect reads) and
+// runs Migrate against it.
+func Connect(ctx context.Context) (*Store, error) {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+		os.Getenv("DB_HOSTNAME"),
+		os.Getenv("DB_PORT"),
+		os.Getenv("DB_USERNAME"),
+		os.Getenv("DB_DATABASE"),
+		os.Getenv("DB_PASSWORD"),
+	)
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("could not open timescale connection pool: %w", err)
+	}
+
+	store := &Store{pool: pool}
+	if err := store.Migrate(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("could not migrate timescale schema: %w", err)
+	}
+
+	return store, nil
+}
+
+func (s *Store) Close() {
+	s.pool.Close()
+}
@@ -0,0 +1,104 @@
+package timescale
+
+import (
+	"context"
+	"fmt"
+)
+
+// Migrate creates the earnings_date_events hypertable (partitioned on
+// earnings_datetime), the earnings_history hypertable, and the continuous
+// aggregates used to retain weekly/monthly surprise summaries once raw
+// rows are compacted away. It's safe to run repeatedly.
+//
+// earnings_date_events is deliberately not named earnings_dates: that name
+// is already owned by the GORM path (internal/db.EarningsDate), which
+// manages its own table keyed by id alone. Partitioning that table on
+// earnings_datetime out from under GORM would break create_hypertable (the
+// partitioning column has to be part of the primary key) and leave both
+// layers writing to the same table under incompatible schemas. The
+// Timescale store keeps every snapshot a source ever reported under its
+// own name instead; GORM's earnings_dates remains the latest-per-stock
+// table it always was.
+func (s *Store) Migrate(ctx context.Context) error {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+
+		`CREATE TABLE IF NOT EXISTS earnings_date_events (
+			id SERIAL,
+			stock_id BIGINT NOT NULL,
+			earnings_datetime TIMESTAMPTZ NOT NULL,
+			source TEXT NOT NULL,
+			PRIMARY KEY (id, earnings_datetime)
+		)`,
+		`SELECT create_hypertable('earnings_date_events', 'earnings_datetime', if_not_exists => TRUE)`,
+		`CREATE INDEX IF NOT EXISTS idx_earnings_date_events_stock_id ON earnings_date_events (stock_id, earnings_datetime DESC)`,
+
+		`CREATE TABLE IF NOT EXISTS earnings_history (
+			stock_id BIGINT NOT NULL,
+			reported_at TIMESTAMPTZ NOT NULL,
+			eps_estimate DOUBLE PRECISION,
+			eps_actual DOUBLE PRECISION,
+			revenue_estimate DOUBLE PRECISION,
+			revenue_actual DOUBLE PRECISION,
+			surprise_pct DOUBLE PRECISION
+		)`,
+		`SELECT create_hypertable('earnings_history', 'reported_at', if_not_exists => TRUE)`,
+		`CREATE INDEX IF NOT EXISTS idx_earnings_history_stock_id ON earnings_history (stock_id, reported_at DESC)`,
+
+		`CREATE MATERIALIZED VIEW IF NOT EXISTS earnings_history_weekly
+			WITH (timescaledb.continuous) AS
+			SELECT
+				stock_id,
+				time_bucket('7 days', reported_at) AS bucket,
+				avg(surprise_pct) AS avg_surprise_pct,
+				count(*) AS sample_count
+			FROM earnings_history
+			GROUP BY stock_id, bucket
+			WITH NO DATA`,
+
+		`CREATE MATERIALIZED VIEW IF NOT EXISTS earnings_history_monthly
+			WITH (timescaledb.continuous) AS
+			SELECT
+				stock_id,
+				time_bucket('30 days', reported_at) AS bucket,
+				avg(surprise_pct) AS avg_surprise_pct,
+				count(*) AS sample_count
+			FROM earnings_history
+			GROUP BY stock_id, bucket
+			WITH NO DATA`,
+
+		// add_continuous_aggregate_policy has no IF NOT EXISTS of its own, and
+		// errors if the aggregate already has a policy attached -- wrap it so
+		// Migrate stays safe to run repeatedly. Without these, the aggregates
+		// created above sit empty forever (WITH NO DATA only creates the
+		// view, it doesn't populate it), so CompactOlderThan would otherwise
+		// be deleting the only copy of the data it claims to be summarizing.
+		`DO $$
+		BEGIN
+			PERFORM add_continuous_aggregate_policy('earnings_history_weekly',
+				start_offset => INTERVAL '3 months',
+				end_offset => INTERVAL '1 day',
+				schedule_interval => INTERVAL '1 day');
+		EXCEPTION WHEN duplicate_object THEN
+			NULL;
+		END $$`,
+
+		`DO $$
+		BEGIN
+			PERFORM add_continuous_aggregate_policy('earnings_history_monthly',
+				start_offset => INTERVAL '3 months',
+				end_offset => INTERVAL '1 day',
+				schedule_interval => INTERVAL '1 day');
+		EXCEPTION WHEN duplicate_object THEN
+			NULL;
+		END $$`,
+	}
+
+	for _, statement := range statements {
+		if _, err := s.pool.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("could not execute migration statement: %w", err)
+		}
+	}
+
+	return nil
+}
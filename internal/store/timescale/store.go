@@ -0,0 +1,110 @@
+package timescale
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/jonathanbell/stonks/internal/store"
+)
+
+func (s *Store) RecordEarningsDate(ctx context.Context, stockID uint, earningsDateTime time.Time, source string) error {
+	_, err := s.pool.Exec(ctx,
+		`INSERT INTO earnings_date_events (stock_id, earnings_datetime, source) VALUES ($1, $2, $3)`,
+		stockID, earningsDateTime, source,
+	)
+	if err != nil {
+		return fmt.Errorf("could not record earnings date: %w", err)
+	}
+
+	return nil
+}
+
+// RecordEarningsHistory bulk-inserts rows via COPY rather than one INSERT
+// per row, since a backfill or a multi-source merge can easily produce
+// hundreds of rows at once.
+func (s *Store) RecordEarningsHistory(ctx context.Context, rows []store.EarningsHistoryRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	source := pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+		row := rows[i]
+		return []any{
+			row.StockID,
+			row.ReportedAt,
+			row.EPSEstimate,
+			row.EPSActual,
+			row.RevenueEstimate,
+			row.RevenueActual,
+			row.SurprisePct,
+		}, nil
+	})
+
+	_, err := s.pool.CopyFrom(
+		ctx,
+		pgx.Identifier{"earnings_history"},
+		[]string{"stock_id", "reported_at", "eps_estimate", "eps_actual", "revenue_estimate", "revenue_actual", "surprise_pct"},
+		source,
+	)
+	if err != nil {
+		return fmt.Errorf("could not bulk-insert earnings history: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) GetEarningsHistory(ctx context.Context, stockID uint, from, to time.Time) ([]store.EarningsHistoryRow, error) {
+	rows, err := s.pool.Query(ctx,
+		`SELECT stock_id, reported_at, eps_estimate, eps_actual, revenue_estimate, revenue_actual, surprise_pct
+		 FROM earnings_history
+		 WHERE stock_id = $1 AND reported_at BETWEEN $2 AND $3
+		 ORDER BY reported_at ASC`,
+		stockID, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not query earnings history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []store.EarningsHistoryRow
+	for rows.Next() {
+		var row store.EarningsHistoryRow
+		if err := rows.Scan(&row.StockID, &row.ReportedAt, &row.EPSEstimate, &row.EPSActual, &row.RevenueEstimate, &row.RevenueActual, &row.SurprisePct); err != nil {
+			return nil, fmt.Errorf("could not scan earnings history row: %w", err)
+		}
+		history = append(history, row)
+	}
+
+	return history, rows.Err()
+}
+
+// CompactOlderThan drops raw earnings_history rows older than maxAge,
+// relying on the weekly/monthly continuous aggregates to retain the
+// summaries those rows fed into. Call this periodically (e.g. daily) with
+// a maxAge of 2 years.
+//
+// The add_continuous_aggregate_policy jobs set up in Migrate refresh those
+// aggregates on their own schedule, but that schedule isn't guaranteed to
+// have caught up to cutoff by the time compaction runs -- so the rows
+// about to be deleted are refreshed into both aggregates explicitly first,
+// making sure the summary exists before the raw data it came from doesn't.
+func (s *Store) CompactOlderThan(ctx context.Context, maxAge time.Duration) error {
+	cutoff := time.Now().Add(-maxAge)
+
+	for _, aggregate := range []string{"earnings_history_weekly", "earnings_history_monthly"} {
+		_, err := s.pool.Exec(ctx, `CALL refresh_continuous_aggregate($1::regclass, NULL, $2)`, aggregate, cutoff)
+		if err != nil {
+			return fmt.Errorf("could not refresh %s before compaction: %w", aggregate, err)
+		}
+	}
+
+	_, err := s.pool.Exec(ctx, `DELETE FROM earnings_history WHERE reported_at < $1`, cutoff)
+	if err != nil {
+		return fmt.Errorf("could not compact earnings history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+// These tests exercise the real TimescaleDB wire protocol (hypertable
+// creation, COPY-based bulk insert, continuous aggregates) and are not run
+// as part of `go test ./...`. Bring up the test instance first:
+//
+//	docker compose -f docker-compose.test.yml up -d
+//	go test -tags=integration ./internal/store/timescale/...
+package timescale
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jonathanbell/stonks/internal/store"
+)
+
+func setTestEnv(t *testing.T) {
+	t.Helper()
+
+	env := map[string]string{
+		"DB_HOSTNAME": "localhost",
+		"DB_PORT":     "5433",
+		"DB_USERNAME": "stonks",
+		"DB_PASSWORD": "stonks",
+		"DB_DATABASE": "stonks_test",
+	}
+	for key, value := range env {
+		if os.Getenv(key) == "" {
+			t.Setenv(key, value)
+		}
+	}
+}
+
+func TestStoreRecordAndQueryEarningsHistory(t *testing.T) {
+	setTestEnv(t)
+
+	ctx := context.Background()
+	s, err := Connect(ctx)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer s.Close()
+
+	estimate := 1.23
+	actual := 1.31
+	reportedAt := time.Date(2026, time.July, 20, 0, 0, 0, 0, time.UTC)
+
+	rows := []store.EarningsHistoryRow{
+		{StockID: 1, ReportedAt: reportedAt, EPSEstimate: &estimate, EPSActual: &actual},
+	}
+
+	err = s.RecordEarningsHistory(ctx, rows)
+	if err != nil {
+		t.Fatalf("RecordEarningsHistory() error = %v", err)
+	}
+
+	got, err := s.GetEarningsHistory(ctx, 1, reportedAt.Add(-time.Hour), reportedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetEarningsHistory() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d rows, want 1", len(got))
+	}
+	if *got[0].EPSActual != actual {
+		t.Errorf("EPSActual = %v, want %v", *got[0].EPSActual, actual)
+	}
+}
+
+func TestStoreCompactOlderThan(t *testing.T) {
+	setTestEnv(t)
+
+	ctx := context.Background()
+	s, err := Connect(ctx)
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer s.Close()
+
+	stale := time.Now().Add(-3 * 365 * 24 * time.Hour)
+	rows := []store.EarningsHistoryRow{{StockID: 1, ReportedAt: stale}}
+	if err := s.RecordEarningsHistory(ctx, rows); err != nil {
+		t.Fatalf("RecordEarningsHistory() error = %v", err)
+	}
+
+	if err := s.CompactOlderThan(ctx, 2*365*24*time.Hour); err != nil {
+		t.Fatalf("CompactOlderThan() error = %v", err)
+	}
+
+	got, err := s.GetEarningsHistory(ctx, 1, stale.Add(-time.Hour), stale.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetEarningsHistory() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %d rows after compaction, want 0", len(got))
+	}
+}